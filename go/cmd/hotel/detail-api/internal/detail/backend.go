@@ -0,0 +1,39 @@
+// Package detail provides pluggable lookup backends for DetailService.
+package detail
+
+import (
+	"context"
+	"fmt"
+
+	detailv1 "github.com/kazukousen/x-monorepo/protos/gen/proto/go/detail/v1"
+)
+
+// Backend resolves hotel IDs into full Hotel records.
+type Backend interface {
+	Lookup(ctx context.Context, ids []string) ([]*detailv1.Hotel, error)
+}
+
+// Factory builds a Backend from raw config, e.g. a file path or a
+// comma-separated address list, as understood by each implementation.
+type Factory func(config string) (Backend, error)
+
+var factories = map[string]Factory{}
+
+// Register makes a backend factory available under name for use with New.
+// It panics on duplicate registration, mirroring the std library's
+// database/sql driver registry.
+func Register(name string, factory Factory) {
+	if _, exists := factories[name]; exists {
+		panic(fmt.Sprintf("detail: backend %q already registered", name))
+	}
+	factories[name] = factory
+}
+
+// New builds the registered backend named name, passing it config.
+func New(name, config string) (Backend, error) {
+	factory, ok := factories[name]
+	if !ok {
+		return nil, fmt.Errorf("detail: unknown backend %q", name)
+	}
+	return factory(config)
+}
@@ -0,0 +1,19 @@
+package detail
+
+import "testing"
+
+func TestNewUnknownBackend(t *testing.T) {
+	if _, err := New("does-not-exist", ""); err == nil {
+		t.Fatal("New with an unregistered backend name = nil error, want one")
+	}
+}
+
+func TestNewMemoryBackend(t *testing.T) {
+	b, err := New("memory", "")
+	if err != nil {
+		t.Fatalf("New(\"memory\", \"\"): %v", err)
+	}
+	if _, ok := b.(*MemoryBackend); !ok {
+		t.Fatalf("New(\"memory\", \"\") = %T, want *MemoryBackend", b)
+	}
+}
@@ -0,0 +1,67 @@
+package detail
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	detailv1 "github.com/kazukousen/x-monorepo/protos/gen/proto/go/detail/v1"
+)
+
+func init() {
+	Register("grpc-proxy", func(config string) (Backend, error) {
+		return NewGRPCProxyBackend(strings.Split(config, ","))
+	})
+}
+
+// GRPCProxyBackend forwards lookups to an upstream DetailService, picking a
+// random node out of addrs on each call. This spreads load across replicas
+// without needing a full client-side load balancer.
+type GRPCProxyBackend struct {
+	clients []detailv1.DetailServiceClient
+}
+
+// NewGRPCProxyBackend dials every address in addrs and returns a backend
+// that forwards to one chosen at random per request.
+func NewGRPCProxyBackend(addrs []string) (*GRPCProxyBackend, error) {
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("detail: grpc-proxy backend requires at least one upstream address")
+	}
+
+	clients := make([]detailv1.DetailServiceClient, 0, len(addrs))
+	for _, addr := range addrs {
+		addr = strings.TrimSpace(addr)
+		if addr == "" {
+			continue
+		}
+
+		conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return nil, fmt.Errorf("detail: dial upstream %s: %w", addr, err)
+		}
+		clients = append(clients, detailv1.NewDetailServiceClient(conn))
+	}
+
+	if len(clients) == 0 {
+		return nil, fmt.Errorf("detail: grpc-proxy backend requires at least one upstream address")
+	}
+
+	return &GRPCProxyBackend{clients: clients}, nil
+}
+
+func (b *GRPCProxyBackend) Lookup(ctx context.Context, ids []string) ([]*detailv1.Hotel, error) {
+	client := b.clients[rand.Intn(len(b.clients))]
+
+	resp, err := client.GetDetails(ctx, &detailv1.GetDetailsRequest{HotelIds: ids})
+	if err != nil {
+		return nil, fmt.Errorf("detail: upstream GetDetails: %w", err)
+	}
+
+	return resp.Hotels, nil
+}
+
+var _ Backend = (*GRPCProxyBackend)(nil)
@@ -0,0 +1,56 @@
+package detail
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	detailv1 "github.com/kazukousen/x-monorepo/protos/gen/proto/go/detail/v1"
+)
+
+func init() {
+	Register("json-file", func(config string) (Backend, error) {
+		return NewJSONFileBackend(config)
+	})
+}
+
+// JSONFileBackend serves hotels loaded once from a JSON seed file at
+// startup. The file must contain an array of Hotel-shaped objects.
+type JSONFileBackend struct {
+	hotels map[string]*detailv1.Hotel
+}
+
+// NewJSONFileBackend loads the seed file at path into memory.
+func NewJSONFileBackend(path string) (*JSONFileBackend, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("detail: read seed file %s: %w", path, err)
+	}
+
+	var seed []*detailv1.Hotel
+	if err := json.Unmarshal(data, &seed); err != nil {
+		return nil, fmt.Errorf("detail: parse seed file %s: %w", path, err)
+	}
+
+	hotels := make(map[string]*detailv1.Hotel, len(seed))
+	for _, h := range seed {
+		hotels[h.Id] = h
+	}
+
+	return &JSONFileBackend{hotels: hotels}, nil
+}
+
+func (b *JSONFileBackend) Lookup(ctx context.Context, ids []string) ([]*detailv1.Hotel, error) {
+	hotels := make([]*detailv1.Hotel, len(ids))
+	for i, id := range ids {
+		if h, ok := b.hotels[id]; ok {
+			hotels[i] = h
+			continue
+		}
+		hotels[i] = &detailv1.Hotel{Id: id}
+	}
+	return hotels, nil
+}
+
+var _ Backend = (*JSONFileBackend)(nil)
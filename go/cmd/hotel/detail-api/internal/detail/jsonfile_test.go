@@ -0,0 +1,48 @@
+package detail
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeSeed(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "seed.json")
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("writing seed file: %v", err)
+	}
+	return path
+}
+
+func TestJSONFileBackendLookupKnownAndUnknownIDs(t *testing.T) {
+	path := writeSeed(t, `[{"Id": "h1"}, {"Id": "h2"}]`)
+
+	b, err := NewJSONFileBackend(path)
+	if err != nil {
+		t.Fatalf("NewJSONFileBackend: %v", err)
+	}
+
+	hotels, err := b.Lookup(context.Background(), []string{"h1", "unknown"})
+	if err != nil {
+		t.Fatalf("Lookup: %v", err)
+	}
+	if len(hotels) != 2 || hotels[0].Id != "h1" || hotels[1].Id != "unknown" {
+		t.Fatalf("Lookup([h1, unknown]) = %v, want seeded h1 and a fallback for unknown", hotels)
+	}
+}
+
+func TestJSONFileBackendMissingFile(t *testing.T) {
+	if _, err := NewJSONFileBackend(filepath.Join(t.TempDir(), "does-not-exist.json")); err == nil {
+		t.Fatal("NewJSONFileBackend with a missing file = nil error, want one")
+	}
+}
+
+func TestJSONFileBackendInvalidJSON(t *testing.T) {
+	path := writeSeed(t, `not valid json`)
+
+	if _, err := NewJSONFileBackend(path); err == nil {
+		t.Fatal("NewJSONFileBackend with invalid JSON = nil error, want one")
+	}
+}
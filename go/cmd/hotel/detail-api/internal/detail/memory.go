@@ -0,0 +1,33 @@
+package detail
+
+import (
+	"context"
+
+	detailv1 "github.com/kazukousen/x-monorepo/protos/gen/proto/go/detail/v1"
+)
+
+func init() {
+	Register("memory", func(config string) (Backend, error) {
+		return NewMemoryBackend(), nil
+	})
+}
+
+// MemoryBackend echoes each requested ID back as a bare Hotel, matching the
+// previous stub behavior. It's useful for local dev and tests that don't
+// care about real hotel data.
+type MemoryBackend struct{}
+
+// NewMemoryBackend returns a Backend that fabricates a Hotel per ID.
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{}
+}
+
+func (b *MemoryBackend) Lookup(ctx context.Context, ids []string) ([]*detailv1.Hotel, error) {
+	hotels := make([]*detailv1.Hotel, len(ids))
+	for i, id := range ids {
+		hotels[i] = &detailv1.Hotel{Id: id}
+	}
+	return hotels, nil
+}
+
+var _ Backend = (*MemoryBackend)(nil)
@@ -0,0 +1,18 @@
+package detail
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMemoryBackendLookupEchoesIDs(t *testing.T) {
+	b := NewMemoryBackend()
+
+	hotels, err := b.Lookup(context.Background(), []string{"h1", "h2"})
+	if err != nil {
+		t.Fatalf("Lookup: %v", err)
+	}
+	if len(hotels) != 2 || hotels[0].Id != "h1" || hotels[1].Id != "h2" {
+		t.Fatalf("Lookup([h1, h2]) = %v, want hotels echoing each ID", hotels)
+	}
+}
@@ -0,0 +1,89 @@
+// Package gateway mounts an HTTP+JSON front end for DetailService, so
+// non-gRPC clients (browsers, curl, existing REST consumers) can reach it
+// without speaking gRPC themselves.
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	detailv1 "github.com/kazukousen/x-monorepo/protos/gen/proto/go/detail/v1"
+)
+
+// Options configures the gateway's HTTP listener.
+type Options struct {
+	// Addr is the address the gateway's HTTP server listens on.
+	Addr string
+
+	// Upstream is the address of the gRPC server backing the gateway,
+	// e.g. the detail-api's own gRPC listen address.
+	Upstream string
+
+	// ForwardHeaders lists HTTP request headers that are passed through
+	// to the upstream gRPC call as metadata, in addition to the gateway's
+	// own defaults.
+	ForwardHeaders []string
+}
+
+// routes lists the HTTP+JSON routes New mounts, kept in sync by hand with
+// the proto's google.api.http annotations. New logs this table rather than
+// a route count or name baked into a single log line, so the startup log
+// stays accurate as routes are added.
+var routes = []struct {
+	method  string
+	pattern string
+	rpc     string
+}{
+	{http.MethodPost, "/v1/hotels:getDetails", "DetailService.GetDetails"},
+}
+
+// New dials opts.Upstream and returns an *http.Server that transcodes
+// HTTP+JSON requests into calls against DetailService, routed per the
+// proto's google.api.http annotations. It accepts and returns both JSON
+// (the default) and binary protobuf, negotiated via Content-Type/Accept,
+// and logs the routes it registers.
+func New(ctx context.Context, opts Options) (*http.Server, error) {
+	conn, err := grpc.NewClient(opts.Upstream, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("gateway: dial upstream %s: %w", opts.Upstream, err)
+	}
+
+	mux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(headerMatcher(opts.ForwardHeaders)),
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{}),
+		runtime.WithMarshalerOption("application/x-protobuf", &runtime.ProtoMarshaller{}),
+	)
+
+	if err := detailv1.RegisterDetailServiceHandler(ctx, mux, conn); err != nil {
+		return nil, fmt.Errorf("gateway: register DetailService handler: %w", err)
+	}
+
+	for _, r := range routes {
+		log.Printf("gateway: registered HTTP+JSON route %s %s -> %s (%s)", r.method, r.pattern, opts.Upstream, r.rpc)
+	}
+
+	return &http.Server{Addr: opts.Addr, Handler: mux}, nil
+}
+
+// headerMatcher forwards any header in allow (case-insensitive) to gRPC
+// metadata, on top of grpc-gateway's own Grpc-Metadata-* defaults.
+func headerMatcher(allow []string) runtime.HeaderMatcherFunc {
+	set := make(map[string]struct{}, len(allow))
+	for _, h := range allow {
+		set[strings.ToLower(h)] = struct{}{}
+	}
+
+	return func(key string) (string, bool) {
+		if _, ok := set[strings.ToLower(key)]; ok {
+			return runtime.MetadataPrefix + key, true
+		}
+		return runtime.DefaultHeaderMatcher(key)
+	}
+}
@@ -0,0 +1,110 @@
+// Package server wraps a *grpc.Server with listen/serve/shutdown lifecycle
+// management so main can focus on wiring.
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+)
+
+// DefaultShutdownTimeout is used when Options.ShutdownTimeout is unset.
+const DefaultShutdownTimeout = 10 * time.Second
+
+// Options configures a Server.
+type Options struct {
+	// Addr is the address to listen on, e.g. ":8080".
+	Addr string
+
+	// ShutdownTimeout bounds how long GracefulStop is given to drain
+	// in-flight RPCs before Stop is called instead. Defaults to
+	// DefaultShutdownTimeout when <= 0.
+	ShutdownTimeout time.Duration
+
+	// Ready, if set, is closed once the listener is bound and BoundAddress
+	// is safe to call. Orchestrators and tests can call Start in a
+	// goroutine and block on Ready instead of polling.
+	Ready chan<- struct{}
+}
+
+// Server runs a *grpc.Server to completion, handling listen, serve, and a
+// bounded graceful shutdown when ctx is canceled.
+type Server struct {
+	grpcServer *grpc.Server
+	opts       Options
+
+	lis net.Listener
+}
+
+// New returns a Server for grpcServer. grpcServer should already have its
+// services registered.
+func New(grpcServer *grpc.Server, opts Options) *Server {
+	if opts.ShutdownTimeout <= 0 {
+		opts.ShutdownTimeout = DefaultShutdownTimeout
+	}
+
+	return &Server{
+		grpcServer: grpcServer,
+		opts:       opts,
+	}
+}
+
+// Start listens on s.opts.Addr and serves until ctx is canceled or serving
+// fails. On cancellation it attempts a graceful stop, falling back to a
+// hard stop if that doesn't complete within s.opts.ShutdownTimeout. It
+// blocks until the server has fully stopped.
+func (s *Server) Start(ctx context.Context) error {
+	lis, err := net.Listen("tcp", s.opts.Addr)
+	if err != nil {
+		return fmt.Errorf("server: listen %s: %w", s.opts.Addr, err)
+	}
+	s.lis = lis
+
+	if s.opts.Ready != nil {
+		close(s.opts.Ready)
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		if err := s.grpcServer.Serve(lis); err != nil {
+			return fmt.Errorf("server: serve: %w", err)
+		}
+		return nil
+	})
+
+	g.Go(func() error {
+		<-ctx.Done()
+		s.shutdown()
+		return nil
+	})
+
+	return g.Wait()
+}
+
+// BoundAddress returns the address the server is listening on. It is only
+// valid once Start has been called.
+func (s *Server) BoundAddress() string {
+	if s.lis == nil {
+		return ""
+	}
+	return s.lis.Addr().String()
+}
+
+func (s *Server) shutdown() {
+	stopped := make(chan struct{})
+	go func() {
+		s.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(s.opts.ShutdownTimeout):
+		s.grpcServer.Stop()
+	}
+}
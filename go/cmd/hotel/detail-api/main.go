@@ -2,73 +2,146 @@ package main
 
 import (
 	"context"
+	"flag"
 	"log"
-	"net"
+	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 
+	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 
+	"github.com/kazukousen/x-monorepo/go/cmd/hotel/detail-api/internal/detail"
+	"github.com/kazukousen/x-monorepo/go/cmd/hotel/detail-api/internal/gateway"
+	"github.com/kazukousen/x-monorepo/go/cmd/hotel/detail-api/internal/server"
+	"github.com/kazukousen/x-monorepo/go/internal/grpcmw"
 	detailv1 "github.com/kazukousen/x-monorepo/protos/gen/proto/go/detail/v1"
 )
 
-func main() {
-
-	svc := &service{}
+const grpcAddr = ":8080"
 
-	s := grpc.NewServer()
-	detailv1.RegisterDetailServiceServer(s, svc)
-	reflection.Register(s)
-
-	lis, err := net.Listen("tcp", ":8080")
+func main() {
+	backendName := flag.String("backend", envOr("DETAIL_BACKEND", "memory"), "detail backend to use: memory, json-file, grpc-proxy")
+	backendConfig := flag.String("backend-config", envOr("DETAIL_BACKEND_CONFIG", ""), "backend-specific config, e.g. a seed file path or comma-separated upstream addresses")
+	adminAddr := flag.String("admin-addr", envOr("DETAIL_ADMIN_ADDR", ":8081"), "address for the admin HTTP listener (/healthz, /readyz, /metrics)")
+	authToken := flag.String("auth-token", envOr("DETAIL_AUTH_TOKEN", ""), "if set, require this bearer token on every RPC")
+	maxBatchSize := flag.Int("max-batch-size", envIntOr("DETAIL_MAX_BATCH_SIZE", 100), "reject GetDetails requests asking for more than this many hotel IDs")
+	maxConcurrency := flag.Int("max-concurrency", envIntOr("DETAIL_MAX_CONCURRENCY", 16), "max number of backend lookups GetDetails fans out at once")
+	gatewayAddr := flag.String("gateway-addr", envOr("DETAIL_GATEWAY_ADDR", ":8082"), "address for the HTTP+JSON gateway listener ('' disables it)")
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	backend, err := detail.New(*backendName, *backendConfig)
 	if err != nil {
-		log.Fatalf("unable to listen: %v", err)
+		log.Fatalf("detail: %v", err)
 	}
 
-	go func() {
-		if err := s.Serve(lis); err != nil {
-			log.Fatalf("failed to serve: %v", err)
-		}
-	}()
+	svc := &service{
+		backend:        backend,
+		maxBatchSize:   *maxBatchSize,
+		maxConcurrency: *maxConcurrency,
+	}
 
-	stop := signalHandler()
-	<-stop
+	metrics := grpcmw.NewMetrics()
 
-	s.GracefulStop()
-}
+	mwOpts := []grpcmw.Option{
+		grpcmw.WithRecovery(),
+		grpcmw.WithLogging(log.Default()),
+		grpcmw.WithMetrics(metrics),
+	}
+	if *authToken != "" {
+		mwOpts = append(mwOpts, grpcmw.WithAuth(grpcmw.TokenAuthProvider{
+			Tokens: map[string]struct{}{*authToken: {}},
+		}))
+	}
 
-func signalHandler() <-chan struct{} {
-	stop := make(chan struct{}, 0)
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(grpcmw.UnaryServerInterceptors(mwOpts...)...),
+		grpc.ChainStreamInterceptor(grpcmw.StreamServerInterceptors(mwOpts...)...),
+	)
+	detailv1.RegisterDetailServiceServer(s, svc)
+	reflection.Register(s)
 
-	go func() {
-		quit := make(chan os.Signal, 2)
-		signal.Notify(quit, os.Interrupt, syscall.SIGTERM, syscall.SIGINT)
+	ready := make(chan struct{})
+	metrics.SetReadyFunc(func() bool {
+		select {
+		case <-ready:
+			return true
+		default:
+			return false
+		}
+	})
+
+	srv := server.New(s, server.Options{
+		Addr:            grpcAddr,
+		ShutdownTimeout: server.DefaultShutdownTimeout,
+		Ready:           ready,
+	})
+
+	admin := metrics.AdminServer(*adminAddr)
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		return srv.Start(ctx)
+	})
+	g.Go(func() error {
+		<-ctx.Done()
+		return admin.Close()
+	})
+	g.Go(func() error {
+		if err := admin.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			return err
+		}
+		return nil
+	})
+
+	if *gatewayAddr != "" {
+		gw, err := gateway.New(ctx, gateway.Options{
+			Addr:           *gatewayAddr,
+			Upstream:       grpcAddr,
+			ForwardHeaders: []string{"X-Request-Id"},
+		})
+		if err != nil {
+			log.Fatalf("gateway: %v", err)
+		}
 
-		log.Printf("received signal, wait shutting down: %s", <-quit)
-		close(stop)
-		log.Fatalf("received twice signal, directly exit: %s", <-quit)
-	}()
+		g.Go(func() error {
+			<-ctx.Done()
+			return gw.Close()
+		})
+		g.Go(func() error {
+			if err := gw.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				return err
+			}
+			return nil
+		})
+	}
 
-	return stop
+	if err := g.Wait(); err != nil {
+		log.Fatalf("server: %v", err)
+	}
 }
 
-type service struct {
+func envOr(key, fallback string) string {
+	if v, ok := os.LookupEnv(key); ok {
+		return v
+	}
+	return fallback
 }
 
-func (s service) GetDetails(ctx context.Context, req *detailv1.GetDetailsRequest) (*detailv1.GetDetailsResponse, error) {
-	ret := make([]*detailv1.Hotel, len(req.HotelIds))
-
-	for i, id := range req.HotelIds {
-		ret[i] = &detailv1.Hotel{
-			Id: id,
-		}
+func envIntOr(key string, fallback int) int {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
 	}
-
-	return &detailv1.GetDetailsResponse{
-		Hotels: ret,
-	}, nil
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return fallback
+	}
+	return n
 }
-
-var _ detailv1.DetailServiceServer = (*service)(nil)
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"context"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/kazukousen/x-monorepo/go/cmd/hotel/detail-api/internal/detail"
+	detailv1 "github.com/kazukousen/x-monorepo/protos/gen/proto/go/detail/v1"
+)
+
+// service implements detailv1.DetailServiceServer against a pluggable
+// detail.Backend.
+type service struct {
+	backend detail.Backend
+
+	// maxBatchSize rejects requests asking for more hotel IDs than this in
+	// one call. <= 0 means unbounded.
+	maxBatchSize int
+
+	// maxConcurrency bounds how many backend lookups GetDetails fans out
+	// at once. <= 0 is treated as 1 (sequential).
+	maxConcurrency int
+}
+
+func (s *service) GetDetails(ctx context.Context, req *detailv1.GetDetailsRequest) (*detailv1.GetDetailsResponse, error) {
+	if len(req.HotelIds) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "hotel_ids must not be empty")
+	}
+	if s.maxBatchSize > 0 && len(req.HotelIds) > s.maxBatchSize {
+		return nil, status.Errorf(codes.InvalidArgument, "hotel_ids has %d entries, max is %d", len(req.HotelIds), s.maxBatchSize)
+	}
+
+	uniqueIDs := dedupe(req.HotelIds)
+
+	results, err := s.lookupConcurrently(ctx, uniqueIDs, req.PartialOk)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.PartialOk {
+		ret := make([]*detailv1.HotelResult, len(req.HotelIds))
+		for i, id := range req.HotelIds {
+			r := results[id]
+			hr := &detailv1.HotelResult{Id: id}
+			if r.err != nil {
+				hr.Error = r.err.Error()
+			} else {
+				hr.Hotel = r.hotel
+			}
+			ret[i] = hr
+		}
+		return &detailv1.GetDetailsResponse{Results: ret}, nil
+	}
+
+	ret := make([]*detailv1.Hotel, len(req.HotelIds))
+	for i, id := range req.HotelIds {
+		ret[i] = results[id].hotel
+	}
+
+	return &detailv1.GetDetailsResponse{Hotels: ret}, nil
+}
+
+// dedupe returns ids with duplicates removed, preserving first-seen order.
+func dedupe(ids []string) []string {
+	seen := make(map[string]struct{}, len(ids))
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		unique = append(unique, id)
+	}
+	return unique
+}
+
+// lookupResult is the outcome of resolving a single hotel ID: either hotel
+// is set, or err describes why that ID couldn't be resolved.
+type lookupResult struct {
+	hotel *detailv1.Hotel
+	err   error
+}
+
+// lookupConcurrently resolves each of ids through s.backend, bounded by
+// s.maxConcurrency in-flight lookups at a time.
+//
+// When partialOk is false, it cancels remaining work and returns as soon
+// as any lookup errors, matching GetDetails' fail-fast default; the
+// returned error is that first failure, not whatever incidental
+// context.Canceled a sibling lookup picked up from the cancellation. When
+// partialOk is true, every ID runs to completion and its error (if any)
+// is carried in its lookupResult instead of aborting the batch, so
+// GetDetails can report per-ID failures in HotelResult; the returned error
+// is always nil in that case.
+func (s *service) lookupConcurrently(ctx context.Context, ids []string, partialOk bool) (map[string]lookupResult, error) {
+	concurrency := s.maxConcurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	results := make(map[string]lookupResult, len(ids))
+	var mu sync.Mutex
+	record := func(id string, r lookupResult) {
+		mu.Lock()
+		results[id] = r
+		mu.Unlock()
+	}
+
+	sem := make(chan struct{}, concurrency)
+
+	if !partialOk {
+		g, gctx := errgroup.WithContext(ctx)
+		for _, id := range ids {
+			id := id
+
+			select {
+			case sem <- struct{}{}:
+			case <-gctx.Done():
+				record(id, lookupResult{err: gctx.Err()})
+				continue
+			}
+
+			g.Go(func() error {
+				defer func() { <-sem }()
+
+				found, err := s.backend.Lookup(gctx, []string{id})
+				if err == nil {
+					var hotel *detailv1.Hotel
+					hotel, err = singleHotel(found, id)
+					if err == nil {
+						record(id, lookupResult{hotel: hotel})
+						return nil
+					}
+				}
+				record(id, lookupResult{err: err})
+				return err
+			})
+		}
+		return results, g.Wait()
+	}
+
+	var wg sync.WaitGroup
+	for _, id := range ids {
+		id := id
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			record(id, lookupResult{err: ctx.Err()})
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			found, err := s.backend.Lookup(ctx, []string{id})
+			if err != nil {
+				record(id, lookupResult{err: err})
+				return
+			}
+
+			hotel, err := singleHotel(found, id)
+			if err != nil {
+				record(id, lookupResult{err: err})
+				return
+			}
+
+			record(id, lookupResult{hotel: hotel})
+		}()
+	}
+	wg.Wait()
+
+	return results, nil
+}
+
+// singleHotel extracts the one hotel expected for id out of a backend's
+// Lookup result, erroring instead of panicking if the backend returned
+// fewer (or more) hotels than requested.
+func singleHotel(found []*detailv1.Hotel, id string) (*detailv1.Hotel, error) {
+	if len(found) != 1 {
+		return nil, status.Errorf(codes.Internal, "backend returned %d hotels for id %q, want 1", len(found), id)
+	}
+	return found[0], nil
+}
+
+var _ detailv1.DetailServiceServer = (*service)(nil)
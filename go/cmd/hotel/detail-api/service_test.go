@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	detailv1 "github.com/kazukousen/x-monorepo/protos/gen/proto/go/detail/v1"
+)
+
+// fakeBackend resolves each ID via lookup, or echoes it as a bare Hotel if
+// lookup is nil.
+type fakeBackend struct {
+	lookup func(ctx context.Context, id string) ([]*detailv1.Hotel, error)
+}
+
+func (b *fakeBackend) Lookup(ctx context.Context, ids []string) ([]*detailv1.Hotel, error) {
+	if len(ids) != 1 {
+		return nil, status.Errorf(codes.Internal, "fakeBackend.Lookup called with %d ids, want 1", len(ids))
+	}
+	if b.lookup == nil {
+		return []*detailv1.Hotel{{Id: ids[0]}}, nil
+	}
+	return b.lookup(ctx, ids[0])
+}
+
+func TestDedupe(t *testing.T) {
+	got := dedupe([]string{"a", "b", "a", "c", "b"})
+	want := []string{"a", "b", "c"}
+	if len(got) != len(want) {
+		t.Fatalf("dedupe = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("dedupe = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestGetDetailsFailFastReturnsFirstError(t *testing.T) {
+	wantErr := errors.New("backend down")
+	s := &service{backend: &fakeBackend{lookup: func(ctx context.Context, id string) ([]*detailv1.Hotel, error) {
+		if id == "bad" {
+			return nil, wantErr
+		}
+		return []*detailv1.Hotel{{Id: id}}, nil
+	}}}
+
+	_, err := s.GetDetails(context.Background(), &detailv1.GetDetailsRequest{HotelIds: []string{"good", "bad"}})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("GetDetails error = %v, want %v", err, wantErr)
+	}
+}
+
+func TestGetDetailsFailFastReturnsRootCauseNotSiblingCancellation(t *testing.T) {
+	wantErr := errors.New("backend down")
+	s := &service{
+		maxConcurrency: 4,
+		backend: &fakeBackend{lookup: func(ctx context.Context, id string) ([]*detailv1.Hotel, error) {
+			if id == "bad" {
+				return nil, wantErr
+			}
+			// good1/good2 only return once the errgroup's shared context is
+			// canceled by "bad"'s failure, so they'd race to be recorded as
+			// context.Canceled before lookupConcurrently's caller can tell
+			// that's not the real failure.
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}},
+	}
+
+	_, err := s.GetDetails(context.Background(), &detailv1.GetDetailsRequest{HotelIds: []string{"good1", "good2", "bad"}})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("GetDetails error = %v, want the root-cause error %v, not a sibling's context.Canceled", err, wantErr)
+	}
+}
+
+func TestGetDetailsPartialOkCarriesPerIDErrors(t *testing.T) {
+	s := &service{backend: &fakeBackend{lookup: func(ctx context.Context, id string) ([]*detailv1.Hotel, error) {
+		if id == "bad" {
+			return nil, errors.New("backend down")
+		}
+		return []*detailv1.Hotel{{Id: id}}, nil
+	}}}
+
+	resp, err := s.GetDetails(context.Background(), &detailv1.GetDetailsRequest{
+		HotelIds:  []string{"good", "bad"},
+		PartialOk: true,
+	})
+	if err != nil {
+		t.Fatalf("GetDetails: %v", err)
+	}
+	if len(resp.Results) != 2 {
+		t.Fatalf("GetDetails partial_ok results = %v, want 2 entries", resp.Results)
+	}
+	if resp.Results[0].Hotel == nil || resp.Results[0].Error != "" {
+		t.Errorf("results[0] = %+v, want a resolved hotel and no error", resp.Results[0])
+	}
+	if resp.Results[1].Hotel != nil || resp.Results[1].Error == "" {
+		t.Errorf("results[1] = %+v, want no hotel and a carried error", resp.Results[1])
+	}
+}
+
+func TestGetDetailsRejectsEmptyAndOversizedBatches(t *testing.T) {
+	s := &service{backend: &fakeBackend{}, maxBatchSize: 1}
+
+	if _, err := s.GetDetails(context.Background(), &detailv1.GetDetailsRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("GetDetails with no hotel_ids = %v, want codes.InvalidArgument", err)
+	}
+
+	req := &detailv1.GetDetailsRequest{HotelIds: []string{"a", "b"}}
+	if _, err := s.GetDetails(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("GetDetails over maxBatchSize = %v, want codes.InvalidArgument", err)
+	}
+}
+
+func TestSingleHotelRejectsWrongCount(t *testing.T) {
+	if _, err := singleHotel(nil, "h1"); status.Code(err) != codes.Internal {
+		t.Errorf("singleHotel(nil) = %v, want codes.Internal", err)
+	}
+	if _, err := singleHotel([]*detailv1.Hotel{{Id: "h1"}, {Id: "h2"}}, "h1"); status.Code(err) != codes.Internal {
+		t.Errorf("singleHotel(2 hotels) = %v, want codes.Internal", err)
+	}
+
+	h, err := singleHotel([]*detailv1.Hotel{{Id: "h1"}}, "h1")
+	if err != nil || h.Id != "h1" {
+		t.Errorf("singleHotel(1 hotel) = (%v, %v), want (h1, nil)", h, err)
+	}
+}
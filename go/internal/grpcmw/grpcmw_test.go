@@ -0,0 +1,24 @@
+package grpcmw
+
+import "testing"
+
+func TestUnaryServerInterceptorsNoOptionsIsEmpty(t *testing.T) {
+	interceptors := UnaryServerInterceptors()
+	if len(interceptors) != 0 {
+		t.Fatalf("UnaryServerInterceptors() with no options = %d interceptors, want 0", len(interceptors))
+	}
+}
+
+func TestStreamServerInterceptorsNoOptionsIsEmpty(t *testing.T) {
+	interceptors := StreamServerInterceptors()
+	if len(interceptors) != 0 {
+		t.Fatalf("StreamServerInterceptors() with no options = %d interceptors, want 0", len(interceptors))
+	}
+}
+
+func TestUnaryServerInterceptorsOnlySelectedOptions(t *testing.T) {
+	interceptors := UnaryServerInterceptors(WithRecovery(), WithMetrics(NewMetrics()))
+	if len(interceptors) != 2 {
+		t.Fatalf("UnaryServerInterceptors(recovery, metrics) = %d interceptors, want 2", len(interceptors))
+	}
+}
@@ -0,0 +1,60 @@
+package grpcmw
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+type requestIDKey struct{}
+
+// RequestIDFromContext returns the request ID assigned by the logging
+// interceptor, or "" if none was installed.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+func newRequestID() string {
+	var b [8]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+func loggingUnaryInterceptor(logger *log.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		reqID := newRequestID()
+		ctx = context.WithValue(ctx, requestIDKey{}, reqID)
+
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logger.Printf("request_id=%s method=%s duration=%s err=%v", reqID, info.FullMethod, time.Since(start), err)
+		return resp, err
+	}
+}
+
+func loggingStreamInterceptor(logger *log.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		reqID := newRequestID()
+		ctx := context.WithValue(ss.Context(), requestIDKey{}, reqID)
+
+		start := time.Now()
+		err := handler(srv, &requestIDServerStream{ServerStream: ss, ctx: ctx})
+		logger.Printf("request_id=%s method=%s duration=%s err=%v", reqID, info.FullMethod, time.Since(start), err)
+		return err
+	}
+}
+
+// requestIDServerStream overrides Context to carry the assigned request ID.
+type requestIDServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *requestIDServerStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,65 @@
+package grpcmw
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+var errMissingCredentials = errors.New("grpcmw: missing or malformed authorization metadata")
+var errInvalidCredentials = errors.New("grpcmw: invalid credentials")
+
+func bearerToken(md metadata.MD, prefix string) (string, bool) {
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return "", false
+	}
+	token, ok := strings.CutPrefix(values[0], prefix)
+	return token, ok
+}
+
+// Authenticate implements AuthProvider.
+func (p BasicAuthProvider) Authenticate(ctx context.Context, md metadata.MD) error {
+	encoded, ok := bearerToken(md, "Basic ")
+	if !ok {
+		return errMissingCredentials
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return errMissingCredentials
+	}
+
+	user, pass, ok := strings.Cut(string(decoded), ":")
+	if !ok {
+		return errMissingCredentials
+	}
+
+	if want, ok := p.Credentials[user]; !ok || want != pass {
+		return errInvalidCredentials
+	}
+
+	return nil
+}
+
+// Authenticate implements AuthProvider.
+func (p TokenAuthProvider) Authenticate(ctx context.Context, md metadata.MD) error {
+	token, ok := bearerToken(md, "Bearer ")
+	if !ok {
+		return errMissingCredentials
+	}
+
+	if _, ok := p.Tokens[token]; !ok {
+		return errInvalidCredentials
+	}
+
+	return nil
+}
+
+var (
+	_ AuthProvider = BasicAuthProvider{}
+	_ AuthProvider = TokenAuthProvider{}
+)
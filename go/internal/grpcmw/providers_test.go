@@ -0,0 +1,49 @@
+package grpcmw
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+)
+
+func TestBasicAuthProviderAuthenticate(t *testing.T) {
+	p := BasicAuthProvider{Credentials: map[string]string{"alice": "hunter2"}}
+
+	ok := base64.StdEncoding.EncodeToString([]byte("alice:hunter2"))
+	md := metadata.Pairs("authorization", "Basic "+ok)
+	if err := p.Authenticate(context.Background(), md); err != nil {
+		t.Fatalf("Authenticate with valid credentials: %v", err)
+	}
+
+	bad := base64.StdEncoding.EncodeToString([]byte("alice:wrong"))
+	md = metadata.Pairs("authorization", "Basic "+bad)
+	if err := p.Authenticate(context.Background(), md); !errors.Is(err, errInvalidCredentials) {
+		t.Fatalf("Authenticate with wrong password = %v, want errInvalidCredentials", err)
+	}
+
+	if err := p.Authenticate(context.Background(), metadata.MD{}); !errors.Is(err, errMissingCredentials) {
+		t.Fatalf("Authenticate with no metadata = %v, want errMissingCredentials", err)
+	}
+}
+
+func TestTokenAuthProviderAuthenticate(t *testing.T) {
+	p := TokenAuthProvider{Tokens: map[string]struct{}{"tok-123": {}}}
+
+	md := metadata.Pairs("authorization", "Bearer tok-123")
+	if err := p.Authenticate(context.Background(), md); err != nil {
+		t.Fatalf("Authenticate with valid token: %v", err)
+	}
+
+	md = metadata.Pairs("authorization", "Bearer wrong")
+	if err := p.Authenticate(context.Background(), md); !errors.Is(err, errInvalidCredentials) {
+		t.Fatalf("Authenticate with wrong token = %v, want errInvalidCredentials", err)
+	}
+
+	md = metadata.Pairs("authorization", "Basic deadbeef")
+	if err := p.Authenticate(context.Background(), md); !errors.Is(err, errMissingCredentials) {
+		t.Fatalf("Authenticate with wrong scheme = %v, want errMissingCredentials", err)
+	}
+}
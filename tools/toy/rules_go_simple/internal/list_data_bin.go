@@ -7,7 +7,7 @@ import (
 )
 
 func main() {
-	files, err := list_data_lib.ListData()
+	files, err := list_data_lib.ListData(list_data_lib.Options{})
 	if err != nil {
 		_, _ = fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
@@ -1,21 +1,104 @@
 package list_data_lib
 
 import (
+	"context"
+	"errors"
 	"io/fs"
-	"path/filepath"
-	"strings"
+	"os"
+	"path"
+	"sync"
 )
 
-func ListData() ([]string, error) {
-	var files []string
-	err := filepath.Walk(".", func(path string, info fs.FileInfo, err error) error {
+// ErrFollowSymlinksUnsupported is returned by WalkData when
+// Options.FollowSymlinks is set. fs.WalkDir has no way to follow a
+// symlinked directory, and silently treating the option as a no-op would
+// make a walk look more thorough than it is, so WalkData fails loudly
+// instead.
+var ErrFollowSymlinksUnsupported = errors.New("list_data_lib: FollowSymlinks is not implemented")
+
+// Options configures how ListData and WalkData traverse a filesystem.
+type Options struct {
+	// Root is the filesystem to walk. Defaults to os.DirFS(".") when nil.
+	Root fs.FS
+
+	// Include is a set of glob patterns (matched against the file's base
+	// name with path.Match) a file must satisfy to be returned. An empty
+	// Include falls back to defaultInclude, preserving the library's
+	// original ".txt"-only behavior.
+	Include []string
+
+	// Exclude is a set of glob patterns that drop a file even if it
+	// satisfies Include.
+	Exclude []string
+
+	// Concurrency bounds how many matched paths WalkData dispatches to its
+	// callback at once. Defaults to 1 (sequential) when <= 0.
+	Concurrency int
+
+	// FollowSymlinks requests that the walk descend into symlinked
+	// directories instead of skipping them. Not yet implemented: WalkData
+	// returns ErrFollowSymlinksUnsupported rather than ignoring it.
+	FollowSymlinks bool
+}
+
+// defaultInclude preserves ListData's original ".txt"-only behavior when
+// Options.Include is empty.
+var defaultInclude = []string{"*.txt"}
+
+func (o Options) root() fs.FS {
+	if o.Root != nil {
+		return o.Root
+	}
+	return os.DirFS(".")
+}
+
+func (o Options) matches(p string) (bool, error) {
+	include := o.Include
+	if len(include) == 0 {
+		include = defaultInclude
+	}
+
+	name := path.Base(p)
+
+	included := false
+	for _, pattern := range include {
+		ok, err := path.Match(pattern, name)
 		if err != nil {
-			return err
+			return false, err
+		}
+		if ok {
+			included = true
+			break
 		}
+	}
+	if !included {
+		return false, nil
+	}
 
-		if !info.IsDir() && strings.HasSuffix(path, ".txt") {
-			files = append(files, path)
+	for _, pattern := range o.Exclude {
+		ok, err := path.Match(pattern, name)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			return false, nil
 		}
+	}
+
+	return true, nil
+}
+
+// ListData walks opts.Root (the current directory when unset) and returns
+// every path matching opts.Include but not opts.Exclude, in the order
+// fs.WalkDir discovers them. opts.Concurrency is ignored: ListData always
+// collects sequentially so its order is deterministic, regardless of what
+// the caller set it to for other uses of opts.
+func ListData(opts Options) ([]string, error) {
+	opts.Concurrency = 1
+
+	var files []string
+	err := WalkData(context.Background(), opts, func(p string) error {
+		files = append(files, p)
 		return nil
 	})
 	if err != nil {
@@ -24,3 +107,109 @@ func ListData() ([]string, error) {
 
 	return files, nil
 }
+
+// WalkData streams matching paths to fn as they're discovered. fn is
+// invoked concurrently across up to opts.Concurrency goroutines; ctx
+// cancellation or a non-nil error from fn stops the walk and is returned
+// to the caller once any in-flight calls finish.
+func WalkData(ctx context.Context, opts Options, fn func(path string) error) error {
+	if opts.FollowSymlinks {
+		return ErrFollowSymlinksUnsupported
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	sem := make(chan struct{}, concurrency)
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+
+	fail := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	walkErr := fs.WalkDir(opts.root(), ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			return fs.SkipAll
+		}
+
+		mu.Lock()
+		stopped := firstErr != nil
+		mu.Unlock()
+		if stopped {
+			return fs.SkipAll
+		}
+
+		if d.IsDir() {
+			if d.Type()&fs.ModeSymlink != 0 {
+				// fs.WalkDir never follows symlinked directories; skip
+				// rather than silently treat it as an (empty) directory.
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		if d.Type()&fs.ModeSymlink != 0 {
+			// Not a real file fs.WalkDir can stat reliably across fs.FS
+			// implementations; skip rather than treat it as a plain file.
+			return nil
+		}
+
+		ok, err := opts.matches(p)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+
+		sem <- struct{}{}
+
+		// Acquiring a slot above can block until an in-flight call
+		// finishes; re-check stopped now rather than the (possibly
+		// stale) read above, so a failure doesn't let one extra path
+		// through per blocked slot.
+		mu.Lock()
+		stopped = firstErr != nil
+		mu.Unlock()
+		if stopped {
+			<-sem
+			return fs.SkipAll
+		}
+
+		wg.Add(1)
+		go func(p string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := fn(p); err != nil {
+				fail(err)
+			}
+		}(p)
+
+		return nil
+	})
+
+	wg.Wait()
+
+	if walkErr != nil && walkErr != fs.SkipAll {
+		return walkErr
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	return firstErr
+}
@@ -0,0 +1,122 @@
+package list_data_lib
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"testing/fstest"
+)
+
+func testFS() fstest.MapFS {
+	return fstest.MapFS{
+		"a.txt":        &fstest.MapFile{},
+		"b.txt":        &fstest.MapFile{},
+		"skip.md":      &fstest.MapFile{},
+		"nested/c.txt": &fstest.MapFile{},
+	}
+}
+
+func TestListDataDefaultIncludesTxtOnly(t *testing.T) {
+	files, err := ListData(Options{Root: testFS()})
+	if err != nil {
+		t.Fatalf("ListData: %v", err)
+	}
+
+	sort.Strings(files)
+	want := []string{"a.txt", "b.txt", "nested/c.txt"}
+	if len(files) != len(want) {
+		t.Fatalf("ListData returned %v, want %v", files, want)
+	}
+	for i := range want {
+		if files[i] != want[i] {
+			t.Errorf("ListData returned %v, want %v", files, want)
+			break
+		}
+	}
+}
+
+func TestListDataIncludeExclude(t *testing.T) {
+	files, err := ListData(Options{
+		Root:    testFS(),
+		Include: []string{"*"},
+		Exclude: []string{"*.md"},
+	})
+	if err != nil {
+		t.Fatalf("ListData: %v", err)
+	}
+
+	sort.Strings(files)
+	want := []string{"a.txt", "b.txt", "nested/c.txt"}
+	if len(files) != len(want) {
+		t.Fatalf("ListData returned %v, want %v", files, want)
+	}
+}
+
+func TestListDataIsDeterministicUnderConcurrency(t *testing.T) {
+	opts := Options{Root: testFS(), Include: []string{"*"}, Concurrency: 8}
+
+	first, err := ListData(opts)
+	if err != nil {
+		t.Fatalf("ListData: %v", err)
+	}
+
+	for i := 0; i < 10; i++ {
+		got, err := ListData(opts)
+		if err != nil {
+			t.Fatalf("ListData: %v", err)
+		}
+		if len(got) != len(first) {
+			t.Fatalf("ListData order varied across calls: %v vs %v", got, first)
+		}
+		for j := range first {
+			if got[j] != first[j] {
+				t.Fatalf("ListData order varied across calls: %v vs %v", got, first)
+			}
+		}
+	}
+}
+
+func TestWalkDataStopsOnError(t *testing.T) {
+	wantErr := errors.New("boom")
+
+	var mu sync.Mutex
+	var calls int
+
+	err := WalkData(context.Background(), Options{Root: testFS(), Include: []string{"*"}, Concurrency: 1}, func(path string) error {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+		return wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("WalkData error = %v, want %v", err, wantErr)
+	}
+	if calls != 1 {
+		t.Fatalf("WalkData invoked fn %d times after the first error, want 1", calls)
+	}
+}
+
+func TestWalkDataRespectsContextCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := WalkData(ctx, Options{Root: testFS(), Include: []string{"*"}}, func(path string) error {
+		t.Fatalf("fn called after context was already canceled")
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("WalkData error = %v, want context.Canceled", err)
+	}
+}
+
+func TestWalkDataRejectsFollowSymlinks(t *testing.T) {
+	err := WalkData(context.Background(), Options{Root: testFS(), FollowSymlinks: true}, func(path string) error {
+		t.Fatalf("fn called despite FollowSymlinks being unsupported")
+		return nil
+	})
+	if !errors.Is(err, ErrFollowSymlinksUnsupported) {
+		t.Fatalf("WalkData error = %v, want ErrFollowSymlinksUnsupported", err)
+	}
+}